@@ -0,0 +1,67 @@
+package lounge
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// TestWithIsRaceFree exercises concurrent With/Debugf/Infof calls on a
+// shared Log. Run with -race to verify derived loggers don't share a
+// mutable pairs map.
+func TestWithIsRaceFree(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewDefaultLog(WithOutput(&buf))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			child := l.With(map[string]string{"worker": fmt.Sprintf("%d", i)})
+			child.Infof("hello from %d", i)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSyncFlushesBufferedOutput(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewDefaultLog(WithOutput(&bufWriter{w: &buf})).(*DefaultLog)
+
+	l.Infof("buffered line")
+
+	if buf.Len() != 0 {
+		t.Fatalf("expected output to still be buffered, got: %q", buf.String())
+	}
+
+	if err := l.Sync(); err != nil {
+		t.Fatalf("unexpected Sync error: %v", err)
+	}
+
+	if buf.Len() == 0 {
+		t.Fatal("expected Sync to flush buffered output")
+	}
+}
+
+// bufWriter wraps a bytes.Buffer behind a Flush method, mimicking
+// bufio.Writer: writes are held in pending, with no size limit, until
+// Flush, so the test doesn't depend on bufio's internal buffer size.
+type bufWriter struct {
+	w       *bytes.Buffer
+	pending []byte
+}
+
+func (b *bufWriter) Write(p []byte) (int, error) {
+	b.pending = append(b.pending, p...)
+	return len(p), nil
+}
+
+func (b *bufWriter) Flush() error {
+	_, err := b.w.Write(b.pending)
+	b.pending = nil
+	return err
+}
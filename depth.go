@@ -0,0 +1,45 @@
+package lounge
+
+// DepthLog is implemented by Logs that can report a caller frame other
+// than their own direct caller. Wrapper libraries - an app-level
+// convenience function, a request-scoped logging middleware, the slog
+// bridge in lounge/slogx - call DebugfDepth/InfofDepth/ErrorfDepth with
+// the number of additional stack frames between themselves and the
+// code that actually wants to be attributed, so debug output keeps
+// pointing at the real call site instead of the wrapper.
+//
+// DepthLog is deliberately not part of Log: most callers never need it,
+// and not every Log implementation can support it.
+type DepthLog interface {
+	DebugfDepth(depth int, fmt string, args ...interface{})
+	InfofDepth(depth int, fmt string, args ...interface{})
+	ErrorfDepth(depth int, fmt string, args ...interface{})
+}
+
+// DebugfDepth is like Debugf, but attributes the log line to the caller
+// depth frames above the caller of DebugfDepth.
+func (dl *DefaultLog) DebugfDepth(depth int, fmtStr string, args ...interface{}) {
+	if dl.enableDebug {
+		dl.printLevel(LevelDebug, true, depth, fmtStr, args...)
+		return
+	}
+
+	frame := getFrame(1 + depth)
+	if !dl.vmodule.enabled(0, frame.File) {
+		return
+	}
+
+	dl.printLevel(LevelDebug, true, depth, fmtStr, args...)
+}
+
+// InfofDepth is like Infof, but attributes the log line to the caller
+// depth frames above the caller of InfofDepth.
+func (dl *DefaultLog) InfofDepth(depth int, fmtStr string, args ...interface{}) {
+	dl.printLevel(LevelInfo, dl.enableDebug, depth, fmtStr, args...)
+}
+
+// ErrorfDepth is like Errorf, but attributes the log line to the caller
+// depth frames above the caller of ErrorfDepth.
+func (dl *DefaultLog) ErrorfDepth(depth int, fmtStr string, args ...interface{}) {
+	dl.printLevel(LevelError, dl.enableDebug, depth, fmtStr, args...)
+}
@@ -0,0 +1,85 @@
+package lounge
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// wrapDebugf simulates an app-level convenience wrapper around Debugf.
+func wrapDebugf(l DepthLog, fmtStr string, args ...interface{}) {
+	l.DebugfDepth(1, fmtStr, args...)
+}
+
+// wrapInfof simulates an app-level convenience wrapper around Infof.
+func wrapInfof(l DepthLog, fmtStr string, args ...interface{}) {
+	l.InfofDepth(1, fmtStr, args...)
+}
+
+// wrapErrorf simulates an app-level convenience wrapper around Errorf.
+func wrapErrorf(l DepthLog, fmtStr string, args ...interface{}) {
+	l.ErrorfDepth(1, fmtStr, args...)
+}
+
+func TestDebugfDepthAttributesToWrapperCaller(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewDefaultLog(WithOutput(&buf), WithDebugEnabled()).(*DefaultLog)
+
+	wrapDebugf(l, "through a wrapper")
+
+	if !strings.Contains(buf.String(), "depth_test.go") {
+		t.Fatalf("expected caller to be attributed to depth_test.go, got: %q", buf.String())
+	}
+}
+
+func TestDebugfAttributesToDirectCaller(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewDefaultLog(WithOutput(&buf), WithDebugEnabled())
+
+	l.Debugf("direct call")
+
+	if !strings.Contains(buf.String(), "depth_test.go") {
+		t.Fatalf("expected caller to be attributed to depth_test.go, got: %q", buf.String())
+	}
+}
+
+// TestInfofDepthAttributesToWrapperCallerLine asserts the exact file#line
+// reported for a wrapped InfofDepth call, not just that it lands somewhere
+// in this file - a plain substring check would still pass with an
+// off-by-one in the frame count, since the wrapper and this test share a
+// file.
+func TestInfofDepthAttributesToWrapperCallerLine(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewDefaultLog(WithOutput(&buf), WithDebugEnabled()).(*DefaultLog)
+
+	_, wantFile, callSiteLine, ok := runtime.Caller(0)
+	wrapInfof(l, "through a wrapper") // this is callSiteLine+1
+	if !ok {
+		t.Fatal("runtime.Caller failed")
+	}
+
+	wantCaller := fmt.Sprintf("%s#%d", wantFile, callSiteLine+1)
+	if !strings.Contains(buf.String(), wantCaller) {
+		t.Fatalf("expected caller %q, got: %q", wantCaller, buf.String())
+	}
+}
+
+// TestErrorfDepthAttributesToWrapperCallerLine is the ErrorfDepth analogue
+// of TestInfofDepthAttributesToWrapperCallerLine.
+func TestErrorfDepthAttributesToWrapperCallerLine(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewDefaultLog(WithOutput(&buf), WithDebugEnabled()).(*DefaultLog)
+
+	_, wantFile, callSiteLine, ok := runtime.Caller(0)
+	wrapErrorf(l, "through a wrapper") // this is callSiteLine+1
+	if !ok {
+		t.Fatal("runtime.Caller failed")
+	}
+
+	wantCaller := fmt.Sprintf("%s#%d", wantFile, callSiteLine+1)
+	if !strings.Contains(buf.String(), wantCaller) {
+		t.Fatalf("expected caller %q, got: %q", wantCaller, buf.String())
+	}
+}
@@ -0,0 +1,198 @@
+package lounge
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// Formatter turns a single log line into the bytes that get written to a
+// Log's output. caller is empty unless the logger has debug enabled or
+// the line is a DEBUG line let through by a vmodule rule.
+type Formatter interface {
+	Format(t time.Time, level string, caller string, pairs map[string]string, msg string) []byte
+}
+
+// outputAwareFormatter is implemented by Formatters (e.g.
+// terminalFormatter) whose behavior depends on the logger's actual
+// output destination, which isn't known until NewDefaultLog has
+// applied every Option.
+type outputAwareFormatter interface {
+	resolveOutput(w io.Writer) Formatter
+}
+
+// WithFormatter sets the Formatter used to render log lines. The default
+// is NewTerminalFormatter(false).
+func WithFormatter(f Formatter) Option {
+	return func(l *DefaultLog) {
+		l.formatter = f
+	}
+}
+
+func sortedKeys(pairs map[string]string) []string {
+	keys := make([]string, 0, len(pairs))
+	for k := range pairs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+type jsonFormatter struct{}
+
+// NewJSONFormatter returns a Formatter that emits one JSON object per
+// line: {"time":..., "level":..., "msg":..., "<pair>":...}.
+func NewJSONFormatter() Formatter {
+	return &jsonFormatter{}
+}
+
+func (f *jsonFormatter) Format(t time.Time, level string, caller string, pairs map[string]string, msg string) []byte {
+	entry := make(map[string]string, len(pairs)+4)
+	for k, v := range pairs {
+		entry[k] = v
+	}
+	entry["time"] = t.Format(time.RFC3339)
+	entry["level"] = level
+	entry["msg"] = msg
+	if caller != "" {
+		entry["caller"] = caller
+	}
+
+	b, err := json.Marshal(entry)
+	if err != nil {
+		b = []byte(`{"level":"ERROR","msg":"lounge: failed to marshal log entry"}`)
+	}
+
+	return append(b, '\n')
+}
+
+type logfmtFormatter struct{}
+
+// NewLogfmtFormatter returns a Formatter that emits logfmt
+// (key=value, space separated), quoting values that contain spaces or
+// quotes.
+func NewLogfmtFormatter() Formatter {
+	return &logfmtFormatter{}
+}
+
+func (f *logfmtFormatter) Format(t time.Time, level string, caller string, pairs map[string]string, msg string) []byte {
+	var b strings.Builder
+
+	writePair := func(k, v string) {
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(logfmtQuote(v))
+	}
+
+	writePair("time", t.Format(time.RFC3339))
+	writePair("level", level)
+	if caller != "" {
+		writePair("caller", caller)
+	}
+	for _, k := range sortedKeys(pairs) {
+		writePair(k, pairs[k])
+	}
+	writePair("msg", msg)
+	b.WriteByte('\n')
+
+	return []byte(b.String())
+}
+
+func logfmtQuote(v string) string {
+	if strings.ContainsAny(v, " \"=") {
+		return strconv.Quote(v)
+	}
+
+	return v
+}
+
+const (
+	ansiReset    = "\033[0m"
+	ansiDebugTag = "\033[36m" // cyan
+	ansiInfoTag  = "\033[32m" // green
+	ansiErrorTag = "\033[31m" // red
+)
+
+type terminalFormatter struct {
+	color bool
+
+	// colorRequested is set when the caller asked for color but the
+	// logger's actual output destination wasn't known yet at
+	// construction time. resolveOutput settles it into color once
+	// NewDefaultLog knows what it's writing to.
+	colorRequested bool
+}
+
+// NewTerminalFormatter returns a Formatter for human-readable output.
+// When color is true, coloring is further gated on the logger's
+// configured output (see WithOutput) actually being a terminal, so
+// colored output doesn't leak ANSI escapes into files or pipes when the
+// output is redirected. That gating happens in NewDefaultLog, once the
+// output is known; a terminalFormatter used outside NewDefaultLog
+// renders without color until resolveOutput is called.
+func NewTerminalFormatter(color bool) Formatter {
+	return &terminalFormatter{colorRequested: color}
+}
+
+// resolveOutput settles colorRequested against w, the logger's actual
+// output destination, now that it's known.
+func (f *terminalFormatter) resolveOutput(w io.Writer) Formatter {
+	color := f.colorRequested
+	if color {
+		if file, ok := w.(*os.File); ok {
+			color = term.IsTerminal(int(file.Fd()))
+		} else {
+			color = false
+		}
+	}
+
+	return &terminalFormatter{color: color}
+}
+
+func (f *terminalFormatter) Format(t time.Time, level string, caller string, pairs map[string]string, msg string) []byte {
+	levelTag := level
+	if f.color {
+		levelTag = f.colorFor(level) + level + ansiReset
+	}
+
+	var b strings.Builder
+	b.WriteString(t.Format(time.RFC3339))
+	b.WriteString(" |")
+	b.WriteString(levelTag)
+	b.WriteString("| ")
+	if caller != "" {
+		b.WriteString(caller)
+		b.WriteString(" ")
+	}
+	for _, k := range sortedKeys(pairs) {
+		b.WriteString(k)
+		b.WriteString("=")
+		b.WriteString(pairs[k])
+		b.WriteString(" ")
+	}
+	b.WriteString(msg)
+	b.WriteString("\n")
+
+	return []byte(b.String())
+}
+
+func (f *terminalFormatter) colorFor(level string) string {
+	switch level {
+	case "DEBUG":
+		return ansiDebugTag
+	case "ERROR":
+		return ansiErrorTag
+	default:
+		return ansiInfoTag
+	}
+}
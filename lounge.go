@@ -2,12 +2,15 @@ package lounge
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -51,18 +54,37 @@ func WithOutput(w io.Writer) Option {
 	}
 }
 
+// WithSink adds an additional Sink that every Entry is fanned out to,
+// alongside the default formatted WithOutput writer. Pass it more than
+// once to add several sinks - e.g. JSON to a file and ERROR-only to a
+// webhook.
+func WithSink(s Sink) Option {
+	return func(l *DefaultLog) {
+		l.extraSinks = append(l.extraSinks, s)
+	}
+}
+
 // NewDefaultLog returns a DefaultLog configured
 // according to the options provided
 func NewDefaultLog(opts ...Option) Log {
 	dl := &DefaultLog{
-		output: bufio.NewWriter(os.Stdout),
-		pairs:  make(map[string]string),
+		output:    bufio.NewWriter(os.Stdout),
+		pairs:     make(map[string]string),
+		formatter: NewTerminalFormatter(false),
+		vmodule:   newVModuleSet(),
+		mu:        &sync.Mutex{},
 	}
 
 	for _, opt := range opts {
 		opt(dl)
 	}
 
+	if f, ok := dl.formatter.(outputAwareFormatter); ok {
+		dl.formatter = f.resolveOutput(dl.output)
+	}
+
+	dl.sink = dl.buildSink()
+
 	return dl
 }
 
@@ -70,60 +92,219 @@ func NewDefaultLog(opts ...Option) Log {
 type DefaultLog struct {
 	pairs map[string]string
 
+	// group is the slog.Handler group prefix, applied to keys added
+	// via WithAttrs after a WithGroup call. Unused outside the
+	// slog.Handler integration.
+	group string
+
 	enableDebug bool
+	vmodule     *vmoduleSet
+
+	output    io.Writer
+	formatter Formatter
+
+	// mu guards writes to output. It is shared (by pointer) across
+	// every *DefaultLog derived from the same root via With/WithGroup,
+	// since they all write to the same output.
+	mu *sync.Mutex
+
+	// extraSinks accumulates WithSink options; only consulted while
+	// building sink in NewDefaultLog.
+	extraSinks []Sink
+
+	// sink is the fully composed destination for Entries: the default
+	// output/formatter writer, fanned out to extraSinks if any were
+	// configured.
+	sink Sink
+}
+
+// buildSink composes dl's default output/formatter writer with any
+// sinks added via WithSink.
+func (dl *DefaultLog) buildSink() Sink {
+	base := newWriterSink(dl.output, dl.formatter, dl.mu)
+	if len(dl.extraSinks) == 0 {
+		return base
+	}
 
-	output io.Writer
+	return MultiSink(append([]Sink{base}, dl.extraSinks...)...)
 }
 
+// With returns a new *DefaultLog carrying a copy of dl's pairs merged
+// with pairs, leaving dl itself untouched. This keeps concurrent
+// callers of With on the same Log from racing on a shared map.
 func (dl *DefaultLog) With(pairs map[string]string) Log {
-	newPairs := dl.pairs
+	newPairs := make(map[string]string, len(dl.pairs)+len(pairs))
+	for k, v := range dl.pairs {
+		newPairs[k] = v
+	}
 	for k, v := range pairs {
 		newPairs[k] = v
 	}
 
-	dl.pairs = newPairs
+	return dl.derive(newPairs, dl.group)
+}
 
-	return dl
+// derive returns a copy of dl with pairs and group replaced, sharing
+// everything else (including the output mutex).
+func (dl *DefaultLog) derive(pairs map[string]string, group string) *DefaultLog {
+	return &DefaultLog{
+		pairs:       pairs,
+		group:       group,
+		enableDebug: dl.enableDebug,
+		vmodule:     dl.vmodule,
+		output:      dl.output,
+		formatter:   dl.formatter,
+		mu:          dl.mu,
+		sink:        dl.sink,
+	}
+}
+
+// Syncer is implemented by Logs that buffer output and need an explicit
+// flush before the process exits, so that buffered lines aren't
+// silently lost on shutdown.
+type Syncer interface {
+	Sync() error
+}
+
+// Sync flushes any buffered output, e.g. the default
+// bufio.NewWriter(os.Stdout). It is a no-op if the configured output
+// doesn't buffer.
+func (dl *DefaultLog) Sync() error {
+	dl.mu.Lock()
+	defer dl.mu.Unlock()
+
+	if f, ok := dl.output.(interface{ Flush() error }); ok {
+		return f.Flush()
+	}
+
+	return nil
 }
 
 func (dl *DefaultLog) Debugf(fmtStr string, args ...interface{}) {
-	if !dl.enableDebug {
+	if dl.enableDebug {
+		dl.printLevel(LevelDebug, true, 0, fmtStr, args...)
 		return
 	}
 
-	dl.printLevel("DEBUG", fmtStr, args...)
+	// global debug is off: fall back to per-module verbosity, so a
+	// vmodule rule for the caller's file can still enable this line.
+	frame := getFrame(1)
+	if !dl.vmodule.enabled(0, frame.File) {
+		return
+	}
+
+	dl.printLevel(LevelDebug, true, 0, fmtStr, args...)
 }
 
 func (dl *DefaultLog) Infof(fmtStr string, args ...interface{}) {
-	dl.printLevel("INFO", fmtStr, args...)
+	dl.printLevel(LevelInfo, dl.enableDebug, 0, fmtStr, args...)
 }
 
 func (dl *DefaultLog) Errorf(fmtStr string, args ...interface{}) {
-	dl.printLevel("ERROR", fmtStr, args...)
+	dl.printLevel(LevelError, dl.enableDebug, 0, fmtStr, args...)
+}
+
+// Enabled implements slog.Handler. DEBUG-level records are only enabled
+// when the logger was built WithDebugEnabled; INFO and above are always
+// enabled.
+func (dl *DefaultLog) Enabled(_ context.Context, level slog.Level) bool {
+	if level < slog.LevelInfo {
+		return dl.enableDebug
+	}
+
+	return true
+}
+
+// handleCallDepth is how many stack frames sit between this Handle and
+// the user's original slog.Logger.Info/Debug/Error call: Logger's
+// exported method, then its internal log helper. It holds for calls made
+// through *slog.Logger's methods, Log and LogAttrs included, which all
+// reach Handle through one internal helper.
+//
+// This is one less than slogx's equivalent slogCallDepth because Handle
+// calls printLevel directly, where slogx's handler goes through an
+// extra DebugfDepth/InfofDepth/ErrorfDepth frame first.
+const handleCallDepth = 2
+
+// Handle implements slog.Handler, dispatching the record to the matching
+// Debugf/Infof/Errorf level and folding its attrs into the logger's pairs.
+func (dl *DefaultLog) Handle(_ context.Context, r slog.Record) error {
+	merged := dl.withRecordAttrs(r)
+
+	switch {
+	case r.Level < slog.LevelInfo:
+		merged.printLevel(LevelDebug, dl.enableDebug, handleCallDepth, "%s", r.Message)
+	case r.Level < slog.LevelError:
+		merged.printLevel(LevelInfo, dl.enableDebug, handleCallDepth, "%s", r.Message)
+	default:
+		merged.printLevel(LevelError, dl.enableDebug, handleCallDepth, "%s", r.Message)
+	}
+
+	return nil
+}
+
+// WithAttrs implements slog.Handler by forwarding to With.
+func (dl *DefaultLog) WithAttrs(attrs []slog.Attr) slog.Handler {
+	pairs := make(map[string]string, len(attrs))
+	for _, a := range attrs {
+		pairs[dl.groupedKey(a.Key)] = a.Value.String()
+	}
+
+	return dl.With(pairs).(*DefaultLog)
 }
 
-func (dl *DefaultLog) printLevel(level string, fmtStr string, args ...interface{}) {
-	currentTime := time.Now().In(time.UTC).Format(time.RFC3339)
+// WithGroup implements slog.Handler. Subsequent attrs (from WithAttrs or
+// a Handle call) are prefixed with the group name.
+func (dl *DefaultLog) WithGroup(name string) slog.Handler {
+	return dl.derive(dl.pairs, dl.groupedKey(name))
+}
+
+func (dl *DefaultLog) groupedKey(name string) string {
+	if dl.group == "" {
+		return name
+	}
+
+	return dl.group + "." + name
+}
 
-	var pairs []string
+// withRecordAttrs returns a *DefaultLog carrying dl's pairs merged with
+// r's attrs, without mutating dl - a Handle call shouldn't permanently
+// attach its attrs to the logger.
+func (dl *DefaultLog) withRecordAttrs(r slog.Record) *DefaultLog {
+	pairs := make(map[string]string, len(dl.pairs)+r.NumAttrs())
 	for k, v := range dl.pairs {
-		pairs = append(pairs, fmt.Sprintf("%s=%s", k, v))
+		pairs[k] = v
 	}
 
-	if dl.enableDebug {
-		caller := getFrame(2)
-		callerWithLine := caller.File + "#" + strconv.Itoa(caller.Line)
+	r.Attrs(func(a slog.Attr) bool {
+		pairs[dl.groupedKey(a.Key)] = a.Value.String()
+		return true
+	})
+
+	return dl.derive(pairs, dl.group)
+}
 
-		gopath, ok := os.LookupEnv("GOPATH")
-		if ok {
+func (dl *DefaultLog) printLevel(level Level, includeCaller bool, depth int, fmtStr string, args ...interface{}) {
+	var caller string
+	if includeCaller {
+		frame := getFrame(2 + depth)
+		caller = frame.File + "#" + strconv.Itoa(frame.Line)
+
+		if gopath, ok := os.LookupEnv("GOPATH"); ok {
 			// remove gopath from log lines
-			callerWithLine = strings.ReplaceAll(callerWithLine, gopath+"/src/", "")
+			caller = strings.ReplaceAll(caller, gopath+"/src/", "")
 		}
-		fmt.Fprintf(dl.output, currentTime+" |"+level+"| "+callerWithLine+" "+strings.Join(pairs, " ")+fmtStr+"\n", args...)
+	}
 
-	} else {
-		fmt.Fprintf(dl.output, currentTime+" |"+level+"| "+strings.Join(pairs, " ")+fmtStr+"\n", args...)
+	entry := Entry{
+		Time:    time.Now().In(time.UTC),
+		Level:   level,
+		Caller:  caller,
+		Pairs:   dl.pairs,
+		Message: fmt.Sprintf(fmtStr, args...),
 	}
+
+	dl.sink.Write(entry)
 }
 
 // https://stackoverflow.com/questions/35212985/is-it-possible-get-information-about-caller-function-in-golang
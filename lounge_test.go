@@ -1,7 +1,10 @@
 package lounge
 
 import (
+	"bytes"
+	"encoding/json"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -15,3 +18,49 @@ func TestLog(t *testing.T) {
 
 	l.Infof("testing log 2: %s", "thigns")
 }
+
+func TestJSONFormatter(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewDefaultLog(WithOutput(&buf), WithFormatter(NewJSONFormatter()))
+
+	l.Infof("testing json: %s", "thing")
+
+	var entry map[string]string
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("expected valid json, got error: %v", err)
+	}
+
+	if entry["msg"] != "testing json: thing" {
+		t.Fatalf("unexpected msg: %q", entry["msg"])
+	}
+	if entry["level"] != "INFO" {
+		t.Fatalf("unexpected level: %q", entry["level"])
+	}
+}
+
+func TestTerminalFormatterColorGatedOnConfiguredOutput(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewDefaultLog(WithOutput(&buf), WithFormatter(NewTerminalFormatter(true)))
+
+	l.Infof("testing color gating")
+
+	if strings.Contains(buf.String(), "\033[") {
+		t.Fatalf("expected no ANSI escapes when the configured output isn't a terminal, got: %q", buf.String())
+	}
+}
+
+func TestLogfmtFormatter(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewDefaultLog(WithOutput(&buf), WithFormatter(NewLogfmtFormatter()))
+
+	l = l.With(map[string]string{"cool": "has space"})
+	l.Infof("testing logfmt")
+
+	line := buf.String()
+	if !strings.Contains(line, `cool="has space"`) {
+		t.Fatalf("expected quoted value with space, got: %q", line)
+	}
+	if !strings.Contains(line, `msg="testing logfmt"`) {
+		t.Fatalf("expected msg pair, got: %q", line)
+	}
+}
@@ -0,0 +1,195 @@
+package lounge
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+// Level identifies the severity of an Entry, in increasing order of
+// severity.
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelError
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelError:
+		return "ERROR"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// Entry is a single log line, built once per Debugf/Infof/Errorf call
+// and dispatched to every configured Sink.
+type Entry struct {
+	Time    time.Time
+	Level   Level
+	Caller  string
+	Pairs   map[string]string
+	Message string
+}
+
+// Sink is a destination for log Entries, e.g. a formatted writer, a
+// filtered or buffered wrapper around another Sink, or a forwarder to
+// an external aggregation service.
+type Sink interface {
+	Write(entry Entry) error
+}
+
+// MultiSink fans an Entry out to every sink, continuing on error and
+// returning all of them joined together.
+func MultiSink(sinks ...Sink) Sink {
+	return &multiSink{sinks: sinks}
+}
+
+type multiSink struct {
+	sinks []Sink
+}
+
+func (m *multiSink) Write(entry Entry) error {
+	var errs []error
+	for _, s := range m.sinks {
+		if err := s.Write(entry); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// LevelFilterSink drops entries below min before forwarding the rest to
+// inner, e.g. to forward only ERROR-level entries to an aggregation
+// service.
+func LevelFilterSink(min Level, inner Sink) Sink {
+	return &levelFilterSink{min: min, inner: inner}
+}
+
+type levelFilterSink struct {
+	min   Level
+	inner Sink
+}
+
+func (l *levelFilterSink) Write(entry Entry) error {
+	if entry.Level < l.min {
+		return nil
+	}
+
+	return l.inner.Write(entry)
+}
+
+// AsyncOverflow controls what an AsyncSink does when its buffer is full.
+type AsyncOverflow int
+
+const (
+	// AsyncBlock blocks the caller until buffer space is available.
+	AsyncBlock AsyncOverflow = iota
+	// AsyncDrop silently drops the entry.
+	AsyncDrop
+)
+
+// AsyncSink writes to inner from a single background goroutine, so
+// slow sinks (a network call, a slow disk) don't block the caller.
+// overflow decides what happens once buffer entries are queued and
+// inner hasn't kept up.
+//
+// AsyncSink has no Close/Stop: its goroutine runs for the lifetime of
+// the process, and DefaultLog.Sync doesn't drain or wait on it.
+// Entries still sitting in the buffer at process exit are lost: callers
+// that need delivery guaranteed before shutdown should keep the buffer
+// small, use AsyncBlock, or avoid AsyncSink for anything that can't be
+// dropped.
+func AsyncSink(buffer int, inner Sink, overflow AsyncOverflow) Sink {
+	s := &asyncSink{
+		inner:    inner,
+		entries:  make(chan Entry, buffer),
+		overflow: overflow,
+	}
+
+	go s.run()
+
+	return s
+}
+
+type asyncSink struct {
+	inner    Sink
+	entries  chan Entry
+	overflow AsyncOverflow
+}
+
+func (a *asyncSink) run() {
+	for entry := range a.entries {
+		// Errors from the background write can't be returned to the
+		// original caller; inner sinks that need durability should
+		// wrap themselves in a FailoverSink.
+		_ = a.inner.Write(entry)
+	}
+}
+
+func (a *asyncSink) Write(entry Entry) error {
+	if a.overflow == AsyncDrop {
+		select {
+		case a.entries <- entry:
+		default:
+		}
+
+		return nil
+	}
+
+	a.entries <- entry
+
+	return nil
+}
+
+// FailoverSink writes to primary, falling back to fallback only when
+// primary returns an error.
+func FailoverSink(primary, fallback Sink) Sink {
+	return &failoverSink{primary: primary, fallback: fallback}
+}
+
+type failoverSink struct {
+	primary  Sink
+	fallback Sink
+}
+
+func (f *failoverSink) Write(entry Entry) error {
+	if err := f.primary.Write(entry); err != nil {
+		return f.fallback.Write(entry)
+	}
+
+	return nil
+}
+
+// writerSink is the Sink backing the default WithOutput/WithFormatter
+// behavior: it renders an Entry with a Formatter and writes the result
+// to an io.Writer, guarded by a shared mutex.
+type writerSink struct {
+	w         io.Writer
+	formatter Formatter
+	mu        *sync.Mutex
+}
+
+func newWriterSink(w io.Writer, f Formatter, mu *sync.Mutex) Sink {
+	return &writerSink{w: w, formatter: f, mu: mu}
+}
+
+func (s *writerSink) Write(entry Entry) error {
+	line := s.formatter.Format(entry.Time, entry.Level.String(), entry.Caller, entry.Pairs, entry.Message)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.w.Write(line)
+
+	return err
+}
@@ -0,0 +1,220 @@
+package lounge
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordingSink struct {
+	mu      sync.Mutex
+	entries []Entry
+}
+
+func (r *recordingSink) Write(entry Entry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries = append(r.entries, entry)
+
+	return nil
+}
+
+func (r *recordingSink) len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.entries)
+}
+
+func (r *recordingSink) all() []Entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Entry(nil), r.entries...)
+}
+
+// waitForLen polls rec until it has received n entries or timeout elapses.
+func waitForLen(t *testing.T, rec *recordingSink, n int, timeout time.Duration) {
+	t.Helper()
+
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if rec.len() >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	t.Fatalf("timed out waiting for %d entries, got %d", n, rec.len())
+}
+
+// blockingSink blocks inside Write until release is closed, signaling
+// started each time so a test can tell the background goroutine has
+// picked an entry up and is stuck delivering it.
+type blockingSink struct {
+	started chan struct{}
+	release chan struct{}
+	rec     *recordingSink
+}
+
+func newBlockingSink() *blockingSink {
+	return &blockingSink{
+		started: make(chan struct{}, 10),
+		release: make(chan struct{}),
+		rec:     &recordingSink{},
+	}
+}
+
+func (b *blockingSink) Write(entry Entry) error {
+	b.started <- struct{}{}
+	<-b.release
+
+	return b.rec.Write(entry)
+}
+
+func TestWithSinkFansOutToExtraSink(t *testing.T) {
+	var buf bytes.Buffer
+	rec := &recordingSink{}
+
+	l := NewDefaultLog(WithOutput(&buf), WithSink(rec))
+	l.Infof("hello")
+
+	if buf.Len() == 0 {
+		t.Fatal("expected default writer sink to still receive the entry")
+	}
+	if rec.len() != 1 {
+		t.Fatalf("expected extra sink to receive 1 entry, got %d", rec.len())
+	}
+}
+
+func TestLevelFilterSink(t *testing.T) {
+	rec := &recordingSink{}
+	s := LevelFilterSink(LevelError, rec)
+
+	s.Write(Entry{Level: LevelInfo, Message: "skip me"})
+	s.Write(Entry{Level: LevelError, Message: "keep me"})
+
+	if rec.len() != 1 {
+		t.Fatalf("expected only the ERROR entry through, got %d entries", rec.len())
+	}
+}
+
+func TestFailoverSink(t *testing.T) {
+	primary := &failingSink{err: errors.New("boom")}
+	fallback := &recordingSink{}
+
+	s := FailoverSink(primary, fallback)
+	if err := s.Write(Entry{Message: "hi"}); err != nil {
+		t.Fatalf("unexpected error from fallback: %v", err)
+	}
+
+	if fallback.len() != 1 {
+		t.Fatalf("expected fallback to receive the entry after primary failed")
+	}
+}
+
+type failingSink struct {
+	err error
+}
+
+func (f *failingSink) Write(Entry) error {
+	return f.err
+}
+
+func TestMultiSink(t *testing.T) {
+	a := &recordingSink{}
+	b := &recordingSink{}
+
+	s := MultiSink(a, b)
+	s.Write(Entry{Message: "hi"})
+
+	if a.len() != 1 || b.len() != 1 {
+		t.Fatalf("expected both sinks to receive the entry, got a=%d b=%d", a.len(), b.len())
+	}
+}
+
+func TestAsyncSinkPreservesOrder(t *testing.T) {
+	rec := &recordingSink{}
+	s := AsyncSink(10, rec, AsyncBlock)
+
+	for i := 0; i < 5; i++ {
+		if err := s.Write(Entry{Message: fmt.Sprintf("%d", i)}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	waitForLen(t, rec, 5, time.Second)
+
+	for i, entry := range rec.all() {
+		if want := fmt.Sprintf("%d", i); entry.Message != want {
+			t.Fatalf("expected entry %d to be %q, got %q", i, want, entry.Message)
+		}
+	}
+}
+
+func TestAsyncSinkDropsWhenBufferFullAndOverflowIsDrop(t *testing.T) {
+	inner := newBlockingSink()
+	s := AsyncSink(1, inner, AsyncDrop)
+
+	if err := s.Write(Entry{Message: "1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	<-inner.started // background goroutine has taken "1" and is stuck delivering it
+
+	if err := s.Write(Entry{Message: "2"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The buffer (capacity 1) is now full with "2"; this one should be dropped.
+	if err := s.Write(Entry{Message: "3"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	close(inner.release)
+
+	waitForLen(t, inner.rec, 2, time.Second)
+
+	got := inner.rec.all()
+	if got[0].Message != "1" || got[1].Message != "2" {
+		t.Fatalf("expected entries 1 and 2 to survive and 3 to be dropped, got %+v", got)
+	}
+}
+
+func TestAsyncSinkBlocksWhenBufferFullAndOverflowIsBlock(t *testing.T) {
+	inner := newBlockingSink()
+	s := AsyncSink(1, inner, AsyncBlock)
+
+	if err := s.Write(Entry{Message: "1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	<-inner.started // background goroutine has taken "1" and is stuck delivering it
+
+	if err := s.Write(Entry{Message: "2"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The buffer (capacity 1) is now full with "2"; this Write must block
+	// until the goroutine drains it.
+	done := make(chan struct{})
+	go func() {
+		s.Write(Entry{Message: "3"})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("expected Write to block while the buffer is full")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(inner.release)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected blocked Write to return once the buffer drains")
+	}
+
+	waitForLen(t, inner.rec, 3, time.Second)
+}
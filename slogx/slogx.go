@@ -0,0 +1,136 @@
+// Package slogx bridges lounge's opinionated three-level Log interface
+// with the standard library's log/slog package, so lounge loggers can be
+// plugged into slog-based middleware and vice versa.
+package slogx
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/selihc/lounge"
+)
+
+// NewSlogHandler adapts a lounge.Log into an slog.Handler, mapping slog
+// levels onto lounge's DEBUG/INFO/ERROR trio (anything below LevelInfo is
+// DEBUG, anything at or above LevelError is ERROR, everything else is
+// INFO) and forwarding WithAttrs to Log.With. After WithGroup, attr keys
+// from WithAttrs and from records are prefixed with "<group>.".
+func NewSlogHandler(l lounge.Log) slog.Handler {
+	return &handler{log: l}
+}
+
+type handler struct {
+	log   lounge.Log
+	group string
+}
+
+// Enabled always reports true: the underlying Log decides for itself
+// whether DEBUG output is enabled, so filtering happens inside Handle.
+func (h *handler) Enabled(_ context.Context, _ slog.Level) bool {
+	return true
+}
+
+// slogCallDepth is the depth passed to DebugfDepth/InfofDepth/ErrorfDepth
+// so the line is attributed to the user's original
+// slog.Logger.Info/Debug/Error call: counting up from this Handle, past
+// Logger's internal log helper and then Logger's exported method, the
+// third frame is the user's call. It holds for calls made through
+// *slog.Logger's methods, Log and LogAttrs included, which all reach
+// Handle through one internal helper.
+const slogCallDepth = 3
+
+func (h *handler) Handle(_ context.Context, r slog.Record) error {
+	l := h.log
+	if pairs := h.attrPairs(r); len(pairs) > 0 {
+		l = l.With(pairs)
+	}
+
+	depthLog, _ := l.(lounge.DepthLog)
+
+	switch {
+	case r.Level < slog.LevelInfo:
+		if depthLog != nil {
+			depthLog.DebugfDepth(slogCallDepth, "%s", r.Message)
+			return nil
+		}
+		l.Debugf("%s", r.Message)
+	case r.Level < slog.LevelError:
+		if depthLog != nil {
+			depthLog.InfofDepth(slogCallDepth, "%s", r.Message)
+			return nil
+		}
+		l.Infof("%s", r.Message)
+	default:
+		if depthLog != nil {
+			depthLog.ErrorfDepth(slogCallDepth, "%s", r.Message)
+			return nil
+		}
+		l.Errorf("%s", r.Message)
+	}
+
+	return nil
+}
+
+func (h *handler) attrPairs(r slog.Record) map[string]string {
+	pairs := make(map[string]string, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		pairs[h.key(a.Key)] = a.Value.String()
+		return true
+	})
+
+	return pairs
+}
+
+func (h *handler) key(name string) string {
+	if h.group == "" {
+		return name
+	}
+
+	return h.group + "." + name
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	pairs := make(map[string]string, len(attrs))
+	for _, a := range attrs {
+		pairs[h.key(a.Key)] = a.Value.String()
+	}
+
+	return &handler{log: h.log.With(pairs), group: h.group}
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	return &handler{log: h.log, group: h.key(name)}
+}
+
+// FromSlog wraps an *slog.Logger behind the lounge.Log interface, for
+// code that wants to keep using lounge's Debugf/Infof/Errorf API on top
+// of an slog-configured logging pipeline.
+func FromSlog(logger *slog.Logger) lounge.Log {
+	return &slogLog{logger: logger}
+}
+
+type slogLog struct {
+	logger *slog.Logger
+}
+
+func (s *slogLog) With(pairs map[string]string) lounge.Log {
+	args := make([]any, 0, len(pairs)*2)
+	for k, v := range pairs {
+		args = append(args, k, v)
+	}
+
+	return &slogLog{logger: s.logger.With(args...)}
+}
+
+func (s *slogLog) Debugf(fmtStr string, args ...interface{}) {
+	s.logger.Debug(fmt.Sprintf(fmtStr, args...))
+}
+
+func (s *slogLog) Infof(fmtStr string, args ...interface{}) {
+	s.logger.Info(fmt.Sprintf(fmtStr, args...))
+}
+
+func (s *slogLog) Errorf(fmtStr string, args ...interface{}) {
+	s.logger.Error(fmt.Sprintf(fmtStr, args...))
+}
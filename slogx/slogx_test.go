@@ -0,0 +1,194 @@
+package slogx
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/selihc/lounge"
+)
+
+func TestNewSlogHandler(t *testing.T) {
+	var buf bytes.Buffer
+	l := lounge.NewDefaultLog(lounge.WithOutput(&buf))
+
+	logger := slog.New(NewSlogHandler(l))
+	logger.Info("testing slog handler", "cool", "stuff")
+
+	if buf.Len() == 0 {
+		t.Fatal("expected handler to write output")
+	}
+}
+
+// TestNewSlogHandlerAttributesToCallSite asserts the exact file#line
+// reported for a slog.Logger.Info call routed through NewSlogHandler,
+// rather than just checking that some output was written - a plain
+// non-empty check would still pass with the caller pointing into
+// log/slog's internals instead of the real call site.
+func TestNewSlogHandlerAttributesToCallSite(t *testing.T) {
+	var buf bytes.Buffer
+	l := lounge.NewDefaultLog(lounge.WithOutput(&buf), lounge.WithDebugEnabled())
+
+	logger := slog.New(NewSlogHandler(l))
+
+	_, wantFile, callSiteLine, ok := runtime.Caller(0)
+	logger.Info("testing slog handler") // this is callSiteLine+1
+	if !ok {
+		t.Fatal("runtime.Caller failed")
+	}
+
+	wantCaller := fmt.Sprintf("%s#%d", wantFile, callSiteLine+1)
+	if !strings.Contains(buf.String(), wantCaller) {
+		t.Fatalf("expected caller %q, got: %q", wantCaller, buf.String())
+	}
+}
+
+// TestNewSlogHandlerPreservesAttrsAndLevel checks that attrs passed to
+// a slog call and the DEBUG/INFO/ERROR level mapping both survive the
+// adapter, not just that something non-empty gets written.
+func TestNewSlogHandlerPreservesAttrsAndLevel(t *testing.T) {
+	var buf bytes.Buffer
+	l := lounge.NewDefaultLog(lounge.WithOutput(&buf), lounge.WithFormatter(lounge.NewJSONFormatter()), lounge.WithDebugEnabled())
+
+	logger := slog.New(NewSlogHandler(l))
+	logger.Debug("debug msg", "cool", "stuff")
+	logger.Warn("warn msg", "cool", "stuff")
+	logger.Error("error msg", "cool", "stuff")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 log lines, got %d: %q", len(lines), buf.String())
+	}
+
+	wantLevels := []string{"DEBUG", "INFO", "ERROR"} // slog.Warn maps onto lounge's INFO
+	for i, line := range lines {
+		var entry map[string]string
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("line %d: expected valid json, got error: %v (line: %q)", i, err, line)
+		}
+		if entry["level"] != wantLevels[i] {
+			t.Fatalf("line %d: expected level %q, got %q", i, wantLevels[i], entry["level"])
+		}
+		if entry["cool"] != "stuff" {
+			t.Fatalf("line %d: expected attr cool=stuff to survive, got entry: %+v", i, entry)
+		}
+	}
+}
+
+// TestNewSlogHandlerWithGroupPrefixesKeys checks that WithGroup prefixes
+// subsequent attrs with "<group>." as the package doc describes.
+func TestNewSlogHandlerWithGroupPrefixesKeys(t *testing.T) {
+	var buf bytes.Buffer
+	l := lounge.NewDefaultLog(lounge.WithOutput(&buf), lounge.WithFormatter(lounge.NewJSONFormatter()))
+
+	logger := slog.New(NewSlogHandler(l)).WithGroup("req")
+	logger.Info("grouped", "id", "abc")
+
+	var entry map[string]string
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("expected valid json, got error: %v", err)
+	}
+	if entry["req.id"] != "abc" {
+		t.Fatalf("expected grouped key %q, got entry: %+v", "req.id", entry)
+	}
+}
+
+func TestDefaultLogAsHandler(t *testing.T) {
+	var buf bytes.Buffer
+	l := lounge.NewDefaultLog(lounge.WithOutput(&buf)).(slog.Handler)
+
+	logger := slog.New(l)
+	logger.Info("testing DefaultLog as slog.Handler")
+
+	if buf.Len() == 0 {
+		t.Fatal("expected handler to write output")
+	}
+}
+
+// TestDefaultLogAsHandlerAttributesToCallSite is the DefaultLog.Handle
+// analogue of TestNewSlogHandlerAttributesToCallSite: DefaultLog
+// implements slog.Handler directly (chunk0-1's "slog.New(loungeLog)"
+// usage) and needs the same correct call-depth accounting as the
+// slogx.handler adapter.
+func TestDefaultLogAsHandlerAttributesToCallSite(t *testing.T) {
+	var buf bytes.Buffer
+	l := lounge.NewDefaultLog(lounge.WithOutput(&buf), lounge.WithDebugEnabled()).(slog.Handler)
+
+	logger := slog.New(l)
+
+	_, wantFile, callSiteLine, ok := runtime.Caller(0)
+	logger.Info("testing DefaultLog as slog.Handler") // this is callSiteLine+1
+	if !ok {
+		t.Fatal("runtime.Caller failed")
+	}
+
+	wantCaller := fmt.Sprintf("%s#%d", wantFile, callSiteLine+1)
+	if !strings.Contains(buf.String(), wantCaller) {
+		t.Fatalf("expected caller %q, got: %q", wantCaller, buf.String())
+	}
+}
+
+// TestDefaultLogAsHandlerPreservesAttrsAndLevel is the DefaultLog.Handle
+// analogue of TestNewSlogHandlerPreservesAttrsAndLevel.
+func TestDefaultLogAsHandlerPreservesAttrsAndLevel(t *testing.T) {
+	var buf bytes.Buffer
+	l := lounge.NewDefaultLog(lounge.WithOutput(&buf), lounge.WithFormatter(lounge.NewJSONFormatter()), lounge.WithDebugEnabled()).(slog.Handler)
+
+	logger := slog.New(l)
+	logger.Debug("debug msg", "cool", "stuff")
+	logger.Warn("warn msg", "cool", "stuff")
+	logger.Error("error msg", "cool", "stuff")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 log lines, got %d: %q", len(lines), buf.String())
+	}
+
+	wantLevels := []string{"DEBUG", "INFO", "ERROR"} // slog.Warn maps onto lounge's INFO
+	for i, line := range lines {
+		var entry map[string]string
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("line %d: expected valid json, got error: %v (line: %q)", i, err, line)
+		}
+		if entry["level"] != wantLevels[i] {
+			t.Fatalf("line %d: expected level %q, got %q", i, wantLevels[i], entry["level"])
+		}
+		if entry["cool"] != "stuff" {
+			t.Fatalf("line %d: expected attr cool=stuff to survive, got entry: %+v", i, entry)
+		}
+	}
+}
+
+// TestDefaultLogAsHandlerWithGroupPrefixesKeys is the DefaultLog.Handle
+// analogue of TestNewSlogHandlerWithGroupPrefixesKeys.
+func TestDefaultLogAsHandlerWithGroupPrefixesKeys(t *testing.T) {
+	var buf bytes.Buffer
+	l := lounge.NewDefaultLog(lounge.WithOutput(&buf), lounge.WithFormatter(lounge.NewJSONFormatter())).(slog.Handler)
+
+	logger := slog.New(l).WithGroup("req")
+	logger.Info("grouped", "id", "abc")
+
+	var entry map[string]string
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("expected valid json, got error: %v", err)
+	}
+	if entry["req.id"] != "abc" {
+		t.Fatalf("expected grouped key %q, got entry: %+v", "req.id", entry)
+	}
+}
+
+func TestFromSlog(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	l := FromSlog(logger)
+	l.Infof("testing reverse adapter: %s", "thing")
+
+	if buf.Len() == 0 {
+		t.Fatal("expected FromSlog logger to write output")
+	}
+}
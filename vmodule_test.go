@@ -0,0 +1,57 @@
+package lounge
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWithVModuleEnablesDebugForMatchingFile(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewDefaultLog(WithOutput(&buf), WithVModule("vmodule_test.go=1"))
+
+	l.Debugf("should be visible")
+
+	if !strings.Contains(buf.String(), "should be visible") {
+		t.Fatalf("expected vmodule rule to enable debug output, got: %q", buf.String())
+	}
+}
+
+func TestWithVModuleLeavesNonMatchingFilesSuppressed(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewDefaultLog(WithOutput(&buf), WithVModule("does_not_exist.go=5"))
+
+	l.Debugf("should stay hidden")
+
+	if buf.Len() != 0 {
+		t.Fatalf("expected debug output to stay suppressed, got: %q", buf.String())
+	}
+}
+
+func TestSetVModuleRetunesRunningLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewDefaultLog(WithOutput(&buf)).(*DefaultLog)
+
+	l.Debugf("hidden before retune")
+	if buf.Len() != 0 {
+		t.Fatalf("expected no output before SetVModule, got: %q", buf.String())
+	}
+
+	l.SetVModule("vmodule_test.go=1")
+	l.Debugf("visible after retune")
+
+	if !strings.Contains(buf.String(), "visible after retune") {
+		t.Fatalf("expected SetVModule to enable debug output, got: %q", buf.String())
+	}
+}
+
+func TestV(t *testing.T) {
+	l := NewDefaultLog(WithVModule("vmodule_test.go=2")).(*DefaultLog)
+
+	if !l.V(1) {
+		t.Fatal("expected V(1) to be enabled for a rule at level 2")
+	}
+	if l.V(3) {
+		t.Fatal("expected V(3) to be disabled for a rule at level 2")
+	}
+}